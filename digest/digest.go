@@ -0,0 +1,66 @@
+// Package digest computes and verifies the content digests carried by the
+// "unikorn:digest" image property, of the form "algorithm:hex" (e.g.
+// "sha256:<hex>" or "sha512:<hex>").
+package digest
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+)
+
+// Sum streams r through the named algorithm ("sha256" or "sha512") and
+// returns the result in "algorithm:hex" form.
+func Sum(r io.Reader, algorithm string) (string, error) {
+	h, err := newHash(algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := io.Copy(h, r); err != nil {
+		return "", fmt.Errorf("hashing content: %w", err)
+	}
+
+	return algorithm + ":" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Verify streams r through the algorithm embedded in want and reports
+// whether the resulting digest matches it.
+func Verify(r io.Reader, want string) (bool, error) {
+	algorithm, _, err := Parse(want)
+	if err != nil {
+		return false, err
+	}
+
+	got, err := Sum(r, algorithm)
+	if err != nil {
+		return false, err
+	}
+
+	return got == want, nil
+}
+
+// Parse splits a digest of the form "algorithm:hex" into its parts.
+func Parse(digest string) (string, string, error) {
+	algorithm, hex, ok := strings.Cut(digest, ":")
+	if !ok {
+		return "", "", fmt.Errorf("digest %q is not of the form algorithm:hex", digest)
+	}
+
+	return algorithm, hex, nil
+}
+
+func newHash(algorithm string) (hash.Hash, error) {
+	switch algorithm {
+	case "sha256":
+		return sha256.New(), nil
+	case "sha512":
+		return sha512.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported digest algorithm %q", algorithm)
+	}
+}
@@ -0,0 +1,75 @@
+package digest
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSum(t *testing.T) {
+	got, err := Sum(strings.NewReader("hello"), "sha256")
+	if err != nil {
+		t.Fatalf("Sum returned error: %v", err)
+	}
+
+	want := "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+	if got != want {
+		t.Errorf("Sum = %q, want %q", got, want)
+	}
+}
+
+func TestSumUnsupportedAlgorithm(t *testing.T) {
+	if _, err := Sum(strings.NewReader("hello"), "md5"); err == nil {
+		t.Fatal("Sum returned nil error for an unsupported algorithm")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	want, err := Sum(strings.NewReader("hello"), "sha256")
+	if err != nil {
+		t.Fatalf("Sum returned error: %v", err)
+	}
+
+	ok, err := Verify(strings.NewReader("hello"), want)
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+
+	if !ok {
+		t.Error("Verify = false, want true for matching content")
+	}
+}
+
+func TestVerifyMismatch(t *testing.T) {
+	ok, err := Verify(strings.NewReader("goodbye"), "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824")
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+
+	if ok {
+		t.Error("Verify = true, want false for mismatched content")
+	}
+}
+
+func TestVerifyMalformedWant(t *testing.T) {
+	if _, err := Verify(strings.NewReader("hello"), "not-a-digest"); err == nil {
+		t.Fatal("Verify returned nil error for a malformed digest")
+	}
+}
+
+func TestParse(t *testing.T) {
+	algorithm, hex, err := Parse("sha256:abcd")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	if algorithm != "sha256" || hex != "abcd" {
+		t.Errorf("Parse = (%q, %q), want (%q, %q)", algorithm, hex, "sha256", "abcd")
+	}
+}
+
+func TestParseMalformed(t *testing.T) {
+	if _, _, err := Parse("sha256-abcd"); err == nil {
+		t.Fatal("Parse returned nil error for a digest missing the algorithm separator")
+	}
+}
@@ -0,0 +1,213 @@
+// Package gallery exposes a provider's validated images over HTTP, so
+// downstream Unikorn control planes can discover suitable images
+// programmatically instead of shelling out to this CLI.
+package gallery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spjmurray/unikorn-images/providers"
+	"github.com/spjmurray/unikorn-images/schemas"
+)
+
+// Invalid records an image that failed schema validation, with enough
+// detail for a caller to diagnose why without re-running the validator
+// itself.
+type Invalid struct {
+	ID          string               `json:"id"`
+	Name        string               `json:"name"`
+	Message     string               `json:"message"`
+	Diagnostics []schemas.Diagnostic `json:"diagnostics,omitempty"`
+}
+
+// filterProperties maps the query parameters the gallery API accepts to
+// the Unikorn property they filter on.
+var filterProperties = map[string]string{
+	"distro":             "unikorn:os:distro",
+	"version":            "unikorn:os:version",
+	"gpu_vendor":         "unikorn:gpu_vendor",
+	"gpu_models":         "unikorn:gpu_models",
+	"virtualization":     "unikorn:virtualization",
+	"package.kubernetes": "unikorn:package:kubernetes",
+	"package.slurmd":     "unikorn:package:slurmd",
+}
+
+// Cache periodically refreshes a provider's image list and serves the
+// last known-good result, so requests never block on the backend.
+type Cache struct {
+	source providers.ImageSource
+	ttl    time.Duration
+
+	mu      sync.RWMutex
+	valid   []providers.Image
+	invalid []Invalid
+}
+
+// NewCache creates a Cache that validates images from source against
+// their selected schema version, refreshing every ttl.
+func NewCache(source providers.ImageSource, ttl time.Duration) *Cache {
+	return &Cache{
+		source: source,
+		ttl:    ttl,
+	}
+}
+
+// Run refreshes the cache immediately, then again every ttl, until ctx is
+// done. It is intended to be run in its own goroutine.
+func (c *Cache) Run(ctx context.Context) {
+	c.refresh(ctx)
+
+	ticker := time.NewTicker(c.ttl)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.refresh(ctx)
+		}
+	}
+}
+
+func (c *Cache) refresh(ctx context.Context) {
+	result, err := c.source.List(ctx)
+	if err != nil {
+		fmt.Println("gallery: refresh failed:", err)
+		return
+	}
+
+	var valid []providers.Image
+
+	var invalid []Invalid
+
+	for _, image := range result {
+		if !hasUnikornProperties(image) {
+			continue
+		}
+
+		version, err := schemas.For(image.Properties)
+		if err != nil {
+			invalid = append(invalid, Invalid{ID: image.ID, Name: image.Name, Message: err.Error()})
+			continue
+		}
+
+		validation := version.Validate(image.Properties)
+		if validation.Valid {
+			valid = append(valid, image)
+			continue
+		}
+
+		invalid = append(invalid, newInvalid(image, validation))
+	}
+
+	c.mu.Lock()
+	c.valid = valid
+	c.invalid = invalid
+	c.mu.Unlock()
+}
+
+func hasUnikornProperties(image providers.Image) bool {
+	for key := range image.Properties {
+		if strings.HasPrefix(key, "unikorn:") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newInvalid builds a diagnostic record from a failed schema validation,
+// mirroring the detail the "list" subcommand prints to the terminal.
+func newInvalid(image providers.Image, validation schemas.Result) Invalid {
+	return Invalid{
+		ID:          image.ID,
+		Name:        image.Name,
+		Message:     "image does not match its Unikorn schema version",
+		Diagnostics: validation.Diagnostics,
+	}
+}
+
+// Handler returns the HTTP handler serving the gallery API:
+//
+//	GET /v2/images          - validated images, optionally filtered
+//	GET /v2/images/{id}     - a single validated image
+//	GET /v2/images/invalid  - images that failed schema validation
+func (c *Cache) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v2/images", c.handleList)
+	mux.HandleFunc("GET /v2/images/invalid", c.handleInvalid)
+	mux.HandleFunc("GET /v2/images/{id}", c.handleGet)
+
+	return mux
+}
+
+func (c *Cache) handleList(w http.ResponseWriter, r *http.Request) {
+	c.mu.RLock()
+	images := slices.Clone(c.valid)
+	c.mu.RUnlock()
+
+	images = slices.DeleteFunc(images, func(image providers.Image) bool {
+		return !matches(image, r.URL.Query())
+	})
+
+	writeJSON(w, images)
+}
+
+func (c *Cache) handleGet(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, image := range c.valid {
+		if image.ID == id {
+			writeJSON(w, image)
+			return
+		}
+	}
+
+	http.NotFound(w, r)
+}
+
+func (c *Cache) handleInvalid(w http.ResponseWriter, r *http.Request) {
+	c.mu.RLock()
+	invalid := slices.Clone(c.invalid)
+	c.mu.RUnlock()
+
+	writeJSON(w, invalid)
+}
+
+// matches reports whether image satisfies every recognized filter
+// parameter present in query.
+func matches(image providers.Image, query url.Values) bool {
+	for param, property := range filterProperties {
+		want := query.Get(param)
+		if want == "" {
+			continue
+		}
+
+		got, _ := image.Properties[property].(string)
+		if got != want {
+			return false
+		}
+	}
+
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		fmt.Println("gallery: encoding response:", err)
+	}
+}
@@ -0,0 +1,115 @@
+package gallery
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/spjmurray/unikorn-images/providers"
+	"github.com/spjmurray/unikorn-images/schemas"
+)
+
+// fakeVersion validates an image as invalid whenever its
+// "unikorn:os:distro" property is "invalid", so tests can drive both
+// branches of refresh()'s valid/invalid split without a real schema.
+type fakeVersion struct{}
+
+type fakeFields struct{}
+
+func (fakeFields) Text() string { return "fake" }
+
+func (fakeVersion) Validate(properties map[string]any) schemas.Result {
+	if properties["unikorn:os:distro"] == "invalid" {
+		return schemas.Result{
+			Diagnostics: []schemas.Diagnostic{{Field: "unikorn:os:distro", Keyword: "enum"}},
+		}
+	}
+
+	return schemas.Result{Valid: true, Fields: fakeFields{}}
+}
+
+func init() {
+	schemas.Register(schemas.DefaultVersion, fakeVersion{})
+}
+
+type fakeSource struct {
+	images []providers.Image
+}
+
+func (f fakeSource) List(context.Context) ([]providers.Image, error) {
+	return f.images, nil
+}
+
+func TestRefreshSplitsValidAndInvalid(t *testing.T) {
+	images := []providers.Image{
+		{ID: "no-properties", Name: "no-properties"},
+		{ID: "valid", Name: "valid", Properties: map[string]any{"unikorn:os:distro": "ubuntu"}},
+		{ID: "invalid", Name: "invalid", Properties: map[string]any{"unikorn:os:distro": "invalid"}},
+	}
+
+	c := NewCache(fakeSource{images: images}, 0)
+	c.refresh(context.Background())
+
+	if len(c.valid) != 1 || c.valid[0].ID != "valid" {
+		t.Fatalf("valid = %+v, want exactly the image with ID %q", c.valid, "valid")
+	}
+
+	if len(c.invalid) != 1 || c.invalid[0].ID != "invalid" {
+		t.Fatalf("invalid = %+v, want exactly the image with ID %q", c.invalid, "invalid")
+	}
+}
+
+func TestMatches(t *testing.T) {
+	image := providers.Image{
+		Properties: map[string]any{
+			"unikorn:os:distro":  "ubuntu",
+			"unikorn:os:version": "22.04",
+		},
+	}
+
+	tests := []struct {
+		name  string
+		query url.Values
+		want  bool
+	}{
+		{"no filters", url.Values{}, true},
+		{"matching filter", url.Values{"distro": {"ubuntu"}}, true},
+		{"mismatched filter", url.Values{"distro": {"centos"}}, false},
+		{"multiple filters all matching", url.Values{"distro": {"ubuntu"}, "version": {"22.04"}}, true},
+		{"multiple filters one mismatched", url.Values{"distro": {"ubuntu"}, "version": {"20.04"}}, false},
+		{"filter on a missing property", url.Values{"gpu_vendor": {"nvidia"}}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := matches(image, test.query); got != test.want {
+				t.Errorf("matches(%v) = %v, want %v", test.query, got, test.want)
+			}
+		})
+	}
+}
+
+func TestHandleListFiltersByQuery(t *testing.T) {
+	c := NewCache(fakeSource{}, 0)
+	c.valid = []providers.Image{
+		{ID: "ubuntu", Properties: map[string]any{"unikorn:os:distro": "ubuntu"}},
+		{ID: "centos", Properties: map[string]any{"unikorn:os:distro": "centos"}},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v2/images?distro=ubuntu", nil)
+	w := httptest.NewRecorder()
+
+	c.handleList(w, req)
+
+	var got []providers.Image
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+
+	if len(got) != 1 || got[0].ID != "ubuntu" {
+		t.Fatalf("handleList returned %+v, want only the %q image", got, "ubuntu")
+	}
+}
@@ -0,0 +1,137 @@
+package keys
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeKeyPair(t *testing.T) (privatePath, publicPath string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating EC key: %v", err)
+	}
+
+	privBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshaling EC private key: %v", err)
+	}
+
+	pubBytes, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("marshaling EC public key: %v", err)
+	}
+
+	dir := t.TempDir()
+
+	privatePath = filepath.Join(dir, "key.pem")
+	publicPath = filepath.Join(dir, "key.pub.pem")
+
+	if err := os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privBytes}), 0o600); err != nil {
+		t.Fatalf("writing private key: %v", err)
+	}
+
+	if err := os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubBytes}), 0o644); err != nil {
+		t.Fatalf("writing public key: %v", err)
+	}
+
+	return privatePath, publicPath
+}
+
+func TestFileSignerAndVerifierRoundTrip(t *testing.T) {
+	privatePath, publicPath := writeKeyPair(t)
+
+	ctx := context.Background()
+
+	signer, err := ResolveSigner(ctx, privatePath)
+	if err != nil {
+		t.Fatalf("ResolveSigner returned error: %v", err)
+	}
+
+	digest := []byte("some precomputed digest")
+
+	signature, err := signer.Sign(ctx, "sha256", digest)
+	if err != nil {
+		t.Fatalf("Sign returned error: %v", err)
+	}
+
+	verifier, err := ResolveVerifier(ctx, publicPath)
+	if err != nil {
+		t.Fatalf("ResolveVerifier returned error: %v", err)
+	}
+
+	ok, err := verifier.Verify(ctx, "sha256", digest, signature)
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+
+	if !ok {
+		t.Error("Verify = false, want true for a signature produced by the matching private key")
+	}
+
+	if got := verifier.Identity(); got != publicPath {
+		t.Errorf("Identity = %q, want %q", got, publicPath)
+	}
+}
+
+func TestFileVerifierRejectsTamperedSignature(t *testing.T) {
+	privatePath, publicPath := writeKeyPair(t)
+
+	ctx := context.Background()
+
+	signer, err := ResolveSigner(ctx, privatePath)
+	if err != nil {
+		t.Fatalf("ResolveSigner returned error: %v", err)
+	}
+
+	signature, err := signer.Sign(ctx, "sha256", []byte("some precomputed digest"))
+	if err != nil {
+		t.Fatalf("Sign returned error: %v", err)
+	}
+
+	signature[0] ^= 0xff
+
+	verifier, err := ResolveVerifier(ctx, publicPath)
+	if err != nil {
+		t.Fatalf("ResolveVerifier returned error: %v", err)
+	}
+
+	ok, err := verifier.Verify(ctx, "sha256", []byte("some precomputed digest"), signature)
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+
+	if ok {
+		t.Error("Verify = true, want false for a tampered signature")
+	}
+}
+
+func TestResolveSignerMissingFile(t *testing.T) {
+	if _, err := ResolveSigner(context.Background(), "/does/not/exist.pem"); err == nil {
+		t.Fatal("ResolveSigner returned nil error for a missing key file")
+	}
+}
+
+func TestResolveSignerUnsupportedKMSScheme(t *testing.T) {
+	for _, ref := range []string{"azurekms://vault/key", "gcpkms://project/key"} {
+		if _, err := ResolveSigner(context.Background(), ref); err == nil {
+			t.Errorf("ResolveSigner(%q) returned nil error for an unsupported KMS scheme", ref)
+		}
+	}
+}
+
+func TestResolveVerifierUnsupportedKMSScheme(t *testing.T) {
+	for _, ref := range []string{"azurekms://vault/key", "gcpkms://project/key"} {
+		if _, err := ResolveVerifier(context.Background(), ref); err == nil {
+			t.Errorf("ResolveVerifier(%q) returned nil error for an unsupported KMS scheme", ref)
+		}
+	}
+}
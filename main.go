@@ -1,200 +1,634 @@
 package main
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"flag"
 	"fmt"
+	"io"
 	"maps"
+	"net/http"
 	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 	"time"
 
-	"github.com/gophercloud/gophercloud/v2/openstack"
-	"github.com/gophercloud/gophercloud/v2/openstack/config"
-	"github.com/gophercloud/gophercloud/v2/openstack/config/clouds"
-	"github.com/gophercloud/gophercloud/v2/openstack/image/v2/images"
-	"github.com/kaptinlin/jsonschema"
+	"github.com/spjmurray/unikorn-images/digest"
+	"github.com/spjmurray/unikorn-images/gallery"
+	"github.com/spjmurray/unikorn-images/keys"
+	"github.com/spjmurray/unikorn-images/providers"
+	"github.com/spjmurray/unikorn-images/providers/kubevirt"
+	"github.com/spjmurray/unikorn-images/providers/oci"
+	"github.com/spjmurray/unikorn-images/providers/openstack"
+	"github.com/spjmurray/unikorn-images/render"
+	"github.com/spjmurray/unikorn-images/scan"
+	"github.com/spjmurray/unikorn-images/schemas"
+
+	_ "github.com/spjmurray/unikorn-images/schemas/v1"
+	_ "github.com/spjmurray/unikorn-images/schemas/v2"
+	_ "github.com/spjmurray/unikorn-images/schemas/v3"
 )
 
-const schemav2 = `{
-	"type":"object",
-	"required":[
-	  "unikorn:os:kernel",
-	  "unikorn:os:family",
-	  "unikorn:os:distro",
-	  "unikorn:os:version",
-	  "unikorn:virtualization"
-	],
-	"properties":{
-		"unikorn:os:kernel":{
-			"type":"string",
-			"enum":[
-				"linux"
-			]
-		},
-		"unikorn:os:family":{
-                        "type":"string",
-			"enum":[
-				"debian",
-				"redhat"
-			]
-                },
-		"unikorn:os:distro":{
-                        "type":"string",
-			"enum":[
-				"ubuntu",
-				"rocky"
-			]
-                },
-		"unikorn:os:variant":{
-                        "type":"string"
-                },
-		"unikorn:os:codename":{
-                        "type":"string"
-                },
-		"unikorn:os:version":{
-                        "type":"string"
-                },
-		"unikorn:package:kubernetes":{
-                        "type":"string"
-                },
-		"unikorn:package:slurmd":{
-                        "type":"string"
-                },
-		"unikorn:gpu_vendor":{
-                        "type":"string",
-			"enum":[
-				"AMD",
-				"NVIDIA"
-			]
-                },
-		"unikorn:gpu_models":{
-                        "type":"string"
-                },
-		"unikorn:gpu_driver":{
-                        "type":"string"
-                },
-		"unikorn:virtualization":{
-                        "type":"string",
-			"enum":[
-				"any",
-				"baremetal",
-				"virtualized"
-			]
-                },
-		"unikorn:digest":{
-                        "type":"string"
-                }
-	}
-}`
-
-func process(image *images.Image, schema *jsonschema.Schema) {
-	fmt.Println("---")
-	fmt.Println("id:", image.ID)
-	fmt.Println("name:", image.Name)
-	fmt.Println("createdAt:", image.CreatedAt)
-	fmt.Println("sizeGiB:", image.SizeBytes>>30)
-
-	result := schema.Validate(image.Properties)
-	if !result.Valid {
-		fmt.Println("error:")
-		fmt.Println("  message: [1;31mImage does not match Unikorn Schema V2[0m")
-		fmt.Println("  documentation: See https://github.com/unikorn-cloud/specifications/blob/main/specifications/providers/openstack/flavors_and_images.md")
-		fmt.Println("  detail:")
-
-		for errorType := range maps.Keys(result.Errors) {
-			evaluationError := result.Errors[errorType]
-
-			switch errorType {
-			case "properties":
-				fmt.Println("  - message: Object properties failed validation or do not exist")
-				// It may either be pluralized or not...
-				for _, k := range []string{"property", "properties"} {
-					if v, ok := evaluationError.Params[k]; ok {
-						fmt.Println("    properties:", v)
-					}
-				}
+// imageReport is the structured report for a single image, rendered by
+// the "list" subcommand in whichever --output format was requested.
+type imageReport struct {
+	ID            string           `json:"id" yaml:"id"`
+	Name          string           `json:"name" yaml:"name"`
+	CreatedAt     time.Time        `json:"createdAt" yaml:"createdAt"`
+	SizeGiB       int64            `json:"sizeGiB" yaml:"sizeGiB"`
+	Error         *validationError `json:"error,omitempty" yaml:"error,omitempty"`
+	Fields        schemas.Fields   `json:"fields,omitempty" yaml:"fields,omitempty"`
+	Verified      *bool            `json:"verified,omitempty" yaml:"verified,omitempty"`
+	VerifiedError string           `json:"verifiedError,omitempty" yaml:"verifiedError,omitempty"`
+	Signature     *signatureReport `json:"signature,omitempty" yaml:"signature,omitempty"`
+	Scan          *scanReport      `json:"scan,omitempty" yaml:"scan,omitempty"`
+}
+
+// validationError explains why an image failed its schema version,
+// with machine-readable diagnostics for CI consumption alongside the
+// human-readable message.
+type validationError struct {
+	Message       string               `json:"message" yaml:"message"`
+	Documentation string               `json:"documentation,omitempty" yaml:"documentation,omitempty"`
+	Diagnostics   []schemas.Diagnostic `json:"diagnostics,omitempty" yaml:"diagnostics,omitempty"`
+}
+
+// signatureReport records whether an image's "unikorn:signature"
+// property is a genuine signature over its content digest.
+type signatureReport struct {
+	Valid  bool   `json:"valid" yaml:"valid"`
+	Signer string `json:"signer,omitempty" yaml:"signer,omitempty"`
+	Error  string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// scanReport summarizes a Trivy scan of an image's content, as attached
+// by the "scan" subcommand.
+type scanReport struct {
+	Severities map[string]int `json:"severities" yaml:"severities"`
+	TopCVEs    []string       `json:"topCVEs,omitempty" yaml:"topCVEs,omitempty"`
+}
+
+// Text renders the report in this tool's legacy human-readable format.
+func (r *imageReport) Text() string {
+	var b strings.Builder
+
+	fmt.Fprintln(&b, "---")
+	fmt.Fprintln(&b, "id:", r.ID)
+	fmt.Fprintln(&b, "name:", r.Name)
+	fmt.Fprintln(&b, "createdAt:", r.CreatedAt)
+	fmt.Fprintln(&b, "sizeGiB:", r.SizeGiB)
+
+	if r.Error != nil {
+		fmt.Fprintln(&b, "error:")
+		fmt.Fprintln(&b, "  message: \033[1;31m"+r.Error.Message+"\033[0m")
+
+		if r.Error.Documentation != "" {
+			fmt.Fprintln(&b, "  documentation:", r.Error.Documentation)
+		}
+
+		fmt.Fprintln(&b, "  detail:")
+
+		for _, d := range r.Error.Diagnostics {
+			fmt.Fprintln(&b, "  - field:", d.Field)
+			fmt.Fprintln(&b, "    keyword:", d.Keyword)
+
+			if d.Details != nil {
+				fmt.Fprintln(&b, "    details:", d.Details)
 			}
 		}
 
-		return
+		return strings.TrimRight(b.String(), "\n")
 	}
 
-	fmt.Println("os:")
+	fmt.Fprint(&b, r.Fields.Text())
+	fmt.Fprintln(&b)
+
+	if r.Verified != nil {
+		fmt.Fprint(&b, "verified: ", *r.Verified)
 
-	for _, name := range []string{"kernel", "family", "distro", "variant", "codename", "version"} {
-		fmt.Println("  "+name+":", image.Properties["unikorn:os:"+name])
+		if r.VerifiedError != "" {
+			fmt.Fprint(&b, " ("+r.VerifiedError+")")
+		}
+
+		fmt.Fprintln(&b)
 	}
 
-	fmt.Println("package:")
+	if r.Signature != nil {
+		fmt.Fprint(&b, "signature: ", r.Signature.Valid)
+
+		if r.Signature.Error != "" {
+			fmt.Fprint(&b, " ("+r.Signature.Error+")")
+		}
 
-	for _, name := range []string{"kubernetes", "slurmd"} {
-		fmt.Println("  "+name+":", image.Properties["unikorn:package:"+name])
+		fmt.Fprintln(&b)
+
+		if r.Signature.Valid {
+			fmt.Fprintln(&b, "signer:", r.Signature.Signer)
+		}
 	}
 
-	fmt.Println("gpu:")
+	if r.Scan != nil {
+		fmt.Fprintln(&b, "scan:")
+		fmt.Fprintln(&b, "  severities:")
+
+		for _, severity := range scan.SeverityOrder {
+			fmt.Fprintln(&b, "    "+strings.ToLower(severity)+":", r.Scan.Severities[severity])
+		}
 
-	for _, name := range []string{"vendor", "models", "driver"} {
-		fmt.Println("  "+name+":", image.Properties["unikorn:gpu_"+name])
+		fmt.Fprintln(&b, "  topCVEs:", r.Scan.TopCVEs)
 	}
 
-	fmt.Println("virtualization:", image.Properties["unikorn:virtualization"])
-	fmt.Println("digest:", image.Properties["unikorn:digest"])
+	return strings.TrimRight(b.String(), "\n")
 }
 
-func main() {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
-	defer cancel()
+// newSource selects and constructs the providers.ImageSource named by
+// --provider.
+func newSource(provider, kubeconfig, kubeNamespace, ociRepository string) (providers.ImageSource, error) {
+	switch provider {
+	case "openstack":
+		return openstack.New(), nil
+	case "kubevirt":
+		return kubevirt.New(kubeconfig, kubeNamespace)
+	case "oci":
+		if ociRepository == "" {
+			return nil, fmt.Errorf("--oci-repository is required for the oci provider")
+		}
+
+		return oci.New(ociRepository), nil
+	default:
+		return nil, fmt.Errorf("unknown provider %q", provider)
+	}
+}
 
-	compiler := jsonschema.NewCompiler()
+// buildReport validates a single image against its selected schema
+// version and, where the backend supports downloading content, verifies
+// its "unikorn:digest" property against the actual image data and, if
+// present, checks its "unikorn:signature" against publicKey.
+func buildReport(ctx context.Context, source providers.ImageSource, image *providers.Image, publicKey string) render.Record {
+	report := buildImageReport(ctx, source, image, publicKey)
+	return render.Record{Text: report.Text, Payload: report}
+}
+
+// buildImageReport does the work described by buildReport, returning the
+// underlying report so callers that need to enrich it further (the scan
+// subcommand attaches a Scan field) can do so before rendering.
+func buildImageReport(ctx context.Context, source providers.ImageSource, image *providers.Image, publicKey string) *imageReport {
+	report := &imageReport{
+		ID:        image.ID,
+		Name:      image.Name,
+		CreatedAt: image.CreatedAt,
+		SizeGiB:   image.SizeBytes >> 30,
+	}
 
-	schema, err := compiler.Compile([]byte(schemav2))
+	version, err := schemas.For(image.Properties)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		report.Error = &validationError{Message: err.Error()}
+		return report
+	}
+
+	result := version.Validate(image.Properties)
+	if !result.Valid {
+		report.Error = &validationError{
+			Message:       "Image does not match its Unikorn schema version",
+			Documentation: "See https://github.com/unikorn-cloud/specifications/blob/main/specifications/providers/openstack/flavors_and_images.md",
+			Diagnostics:   result.Diagnostics,
+		}
+
+		return report
+	}
+
+	report.Fields = result.Fields
+
+	verifyReport(ctx, source, image, report, publicKey)
+
+	return report
+}
+
+// verifyReport populates report's Verified and Signature fields by
+// checking the image's "unikorn:digest" property against its actual
+// content and, if present, its "unikorn:signature" against publicKey.
+func verifyReport(ctx context.Context, source providers.ImageSource, image *providers.Image, report *imageReport, publicKey string) {
+	wantDigest, ok := image.Properties["unikorn:digest"].(string)
+	if !ok || wantDigest == "" {
+		return
 	}
 
-	authOptions, endpointOpts, tlsConfig, err := clouds.Parse()
+	downloader, ok := source.(providers.Downloader)
+	if !ok {
+		return
+	}
+
+	body, err := downloader.Download(ctx, image.ID)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		verified := false
+		report.Verified = &verified
+		report.VerifiedError = err.Error()
+
+		return
 	}
+	defer body.Close()
 
-	providerClient, err := config.NewProviderClient(ctx, authOptions, config.WithTLSConfig(tlsConfig))
+	verified, err := digest.Verify(body, wantDigest)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		verified = false
+		report.VerifiedError = err.Error()
 	}
 
-	client, err := openstack.NewImageV2(providerClient, endpointOpts)
+	report.Verified = &verified
+
+	if !verified || publicKey == "" {
+		return
+	}
+
+	signature, ok := image.Properties["unikorn:signature"].(string)
+	if !ok || signature == "" {
+		return
+	}
+
+	report.Signature = buildSignatureReport(ctx, wantDigest, signature, publicKey)
+}
+
+// buildSignatureReport reports whether signature is a valid signature
+// over the hex-decoded digest, as produced by the sign subcommand.
+func buildSignatureReport(ctx context.Context, wantDigest, signature, publicKey string) *signatureReport {
+	algorithm, hexDigest, err := digest.Parse(wantDigest)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		return &signatureReport{Error: err.Error()}
 	}
 
-	opts := &images.ListOpts{
-		Visibility: images.ImageVisibilityPublic,
+	verifier, err := keys.ResolveVerifier(ctx, publicKey)
+	if err != nil {
+		return &signatureReport{Error: err.Error()}
 	}
 
-	page, err := images.List(client, opts).AllPages(ctx)
+	digestBytes, err := hex.DecodeString(hexDigest)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		return &signatureReport{Error: err.Error()}
 	}
 
-	result, err := images.ExtractImages(page)
+	signatureBytes, err := hex.DecodeString(signature)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		return &signatureReport{Error: err.Error()}
+	}
+
+	ok, err := verifier.Verify(ctx, algorithm, digestBytes, signatureBytes)
+	if err != nil {
+		return &signatureReport{Error: err.Error()}
+	}
+
+	if !ok {
+		return &signatureReport{Valid: false}
+	}
+
+	return &signatureReport{Valid: true, Signer: verifier.Identity()}
+}
+
+func runList(args []string) error {
+	flags := flag.NewFlagSet("list", flag.ExitOnError)
+
+	provider := flags.String("provider", "openstack", "image backend to query: openstack, kubevirt, oci")
+	kubeconfig := flags.String("kubeconfig", "", "path to a kubeconfig file (kubevirt provider only)")
+	kubeNamespace := flags.String("kube-namespace", "", "namespace to search, or all namespaces if unset (kubevirt provider only)")
+	ociRepository := flags.String("oci-repository", "", "repository to resolve, e.g. registry.example.com/unikorn/images (oci provider only)")
+	publicKey := flags.String("public-key", "", "PEM file or awskms:// URI used to verify unikorn:signature, or empty to skip")
+	output := flags.String("output", "text", "output format: text, yaml, json, ndjson")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	format, err := render.ParseFormat(*output)
+	if err != nil {
+		return err
 	}
 
-	result = slices.DeleteFunc(result, func(image images.Image) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	source, err := newSource(*provider, *kubeconfig, *kubeNamespace, *ociRepository)
+	if err != nil {
+		return err
+	}
+
+	result, err := source.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	result = slices.DeleteFunc(result, func(image providers.Image) bool {
 		return !slices.ContainsFunc(slices.Collect(maps.Keys(image.Properties)), func(key string) bool {
 			return strings.HasPrefix(key, "unikorn:")
 		})
 	})
 
+	records := make([]render.Record, len(result))
+
 	for i := range result {
-		process(&result[i], schema)
+		records[i] = buildReport(ctx, source, &result[i], *publicKey)
+	}
+
+	return render.Write(os.Stdout, format, records)
+}
+
+// runSign computes the content digest of an image, signs it and writes
+// the "unikorn:digest" and "unikorn:signature" properties back onto it.
+// Only backends implementing providers.Downloader and
+// providers.PropertySetter are supported; today that is openstack alone.
+func runSign(args []string) error {
+	flags := flag.NewFlagSet("sign", flag.ExitOnError)
+
+	provider := flags.String("provider", "openstack", "image backend the image belongs to")
+	image := flags.String("image", "", "ID of the image to sign")
+	key := flags.String("key", "", "PEM private key file or KMS URI (awskms://<key-id>; azurekms://, gcpkms:// are not yet supported)")
+	algorithm := flags.String("algorithm", "sha256", "digest algorithm: sha256 or sha512")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *image == "" || *key == "" {
+		return fmt.Errorf("--image and --key are required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	source, err := newSource(*provider, "", "", "")
+	if err != nil {
+		return err
+	}
+
+	downloader, ok := source.(providers.Downloader)
+	if !ok {
+		return fmt.Errorf("provider %q does not support downloading image content", *provider)
+	}
+
+	setter, ok := source.(providers.PropertySetter)
+	if !ok {
+		return fmt.Errorf("provider %q does not support writing image properties", *provider)
+	}
+
+	signer, err := keys.ResolveSigner(ctx, *key)
+	if err != nil {
+		return err
+	}
+
+	body, err := downloader.Download(ctx, *image)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	imageDigest, err := digest.Sum(body, *algorithm)
+	if err != nil {
+		return err
+	}
+
+	_, hexDigest, err := digest.Parse(imageDigest)
+	if err != nil {
+		return err
+	}
+
+	digestBytes, err := hex.DecodeString(hexDigest)
+	if err != nil {
+		return err
+	}
+
+	signature, err := signer.Sign(ctx, *algorithm, digestBytes)
+	if err != nil {
+		return err
+	}
+
+	properties := map[string]string{
+		"unikorn:digest":    imageDigest,
+		"unikorn:signature": hex.EncodeToString(signature),
+	}
+
+	if err := setter.SetProperties(ctx, *image, properties); err != nil {
+		return err
+	}
+
+	fmt.Println("digest:", imageDigest)
+	fmt.Println("signature:", properties["unikorn:signature"])
+
+	return nil
+}
+
+// runScan downloads each validated image and scans it with Trivy,
+// merging the CVE summary into the same per-image report "list" prints,
+// so it can be requested in any of the usual --output formats. It can
+// also upload the raw report as the "unikorn:scan:report" property
+// and/or write a SARIF report per image for CI consumption.
+func runScan(args []string) error {
+	flags := flag.NewFlagSet("scan", flag.ExitOnError)
+
+	provider := flags.String("provider", "openstack", "image backend to scan")
+	kubeconfig := flags.String("kubeconfig", "", "path to a kubeconfig file (kubevirt provider only)")
+	kubeNamespace := flags.String("kube-namespace", "", "namespace to search, or all namespaces if unset (kubevirt provider only)")
+	ociRepository := flags.String("oci-repository", "", "repository to resolve (oci provider only)")
+	failOn := flags.String("fail-on", "", "exit non-zero if any image has a finding at or above this severity: critical, high, medium, low, unknown")
+	sarifDir := flags.String("sarif-dir", "", "directory to write one SARIF report per image, for CI consumption")
+	upload := flags.Bool("upload", false, "upload the report as the unikorn:scan:report property (gzip+base64 JSON)")
+	output := flags.String("output", "text", "output format: text, yaml, json, ndjson")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	format, err := render.ParseFormat(*output)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	source, err := newSource(*provider, *kubeconfig, *kubeNamespace, *ociRepository)
+	if err != nil {
+		return err
+	}
+
+	downloader, ok := source.(providers.Downloader)
+	if !ok {
+		return fmt.Errorf("provider %q does not support downloading image content", *provider)
+	}
+
+	result, err := source.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	result = slices.DeleteFunc(result, func(image providers.Image) bool {
+		version, err := schemas.For(image.Properties)
+		if err != nil {
+			return true
+		}
+
+		return !version.Validate(image.Properties).Valid
+	})
+
+	setter, _ := source.(providers.PropertySetter)
+
+	exceeded := false
+
+	var records []render.Record
+
+	for i := range result {
+		image := &result[i]
+
+		scanned, err := scanImage(ctx, downloader, image, *sarifDir)
+		if err != nil {
+			fmt.Println("error scanning", image.ID+":", err)
+			continue
+		}
+
+		report := buildImageReport(ctx, source, image, "")
+		report.Scan = buildScanReport(scanned)
+		records = append(records, render.Record{Text: report.Text, Payload: report})
+
+		if *upload && setter != nil {
+			if err := uploadScanReport(ctx, setter, image.ID, scanned); err != nil {
+				fmt.Println("error uploading report for", image.ID+":", err)
+			}
+		}
+
+		if *failOn != "" && scanned.Exceeds(strings.ToUpper(*failOn)) {
+			exceeded = true
+		}
+	}
+
+	if err := render.Write(os.Stdout, format, records); err != nil {
+		return err
+	}
+
+	if exceeded {
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+// scanImage downloads image to a temporary file and hands it to Trivy,
+// optionally also writing a SARIF report into sarifDir.
+func scanImage(ctx context.Context, downloader providers.Downloader, image *providers.Image, sarifDir string) (*scan.Report, error) {
+	body, err := downloader.Download(ctx, image.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	f, err := os.CreateTemp("", "unikorn-image-*.img")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if _, err := io.Copy(f, body); err != nil {
+		return nil, err
+	}
+
+	report, err := scan.Run(ctx, f.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	if sarifDir != "" {
+		if err := os.MkdirAll(sarifDir, 0o755); err != nil {
+			return nil, err
+		}
+
+		if err := scan.WriteSARIF(ctx, f.Name(), filepath.Join(sarifDir, image.ID+".sarif")); err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// buildScanReport adapts a Trivy scan.Report into the scanReport shape
+// embedded in imageReport, so scan results render through render.Write
+// alongside every other field "list" already reports.
+func buildScanReport(report *scan.Report) *scanReport {
+	return &scanReport{Severities: report.Counts, TopCVEs: report.Top}
+}
+
+// uploadScanReport gzips and base64-encodes the raw Trivy JSON report and
+// writes it back onto the image as unikorn:scan:report.
+func uploadScanReport(ctx context.Context, setter providers.PropertySetter, id string, report *scan.Report) error {
+	var buf bytes.Buffer
+
+	gz := gzip.NewWriter(&buf)
+
+	if _, err := gz.Write(report.JSON); err != nil {
+		return err
+	}
+
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	return setter.SetProperties(ctx, id, map[string]string{"unikorn:scan:report": encoded})
+}
+
+// runServe periodically refreshes a provider's image list and exposes it
+// over HTTP, so downstream control planes can discover suitable images
+// without shelling out to this CLI.
+func runServe(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+
+	provider := flags.String("provider", "openstack", "image backend to serve")
+	kubeconfig := flags.String("kubeconfig", "", "path to a kubeconfig file (kubevirt provider only)")
+	kubeNamespace := flags.String("kube-namespace", "", "namespace to search, or all namespaces if unset (kubevirt provider only)")
+	ociRepository := flags.String("oci-repository", "", "repository to resolve (oci provider only)")
+	addr := flags.String("addr", ":8080", "address to listen on")
+	ttl := flags.Duration("ttl", 5*time.Minute, "how often to refresh the image list")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	source, err := newSource(*provider, *kubeconfig, *kubeNamespace, *ociRepository)
+	if err != nil {
+		return err
+	}
+
+	cache := gallery.NewCache(source, *ttl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go cache.Run(ctx)
+
+	fmt.Println("listening on", *addr)
+
+	return http.ListenAndServe(*addr, cache.Handler())
+}
+
+func main() {
+	args := os.Args[1:]
+
+	var err error
+
+	switch {
+	case len(args) > 0 && args[0] == "sign":
+		err = runSign(args[1:])
+	case len(args) > 0 && args[0] == "scan":
+		err = runScan(args[1:])
+	case len(args) > 0 && args[0] == "serve":
+		err = runServe(args[1:])
+	default:
+		err = runList(args)
+	}
+
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
 	}
 }
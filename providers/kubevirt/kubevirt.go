@@ -0,0 +1,124 @@
+// Package kubevirt implements a providers.ImageSource backed by KubeVirt
+// DataVolume custom resources, as used to stage disk images for
+// ContainerDisk/PVC backed VirtualMachines on a Kubernetes cluster.
+package kubevirt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/spjmurray/unikorn-images/providers"
+)
+
+// dataVolumeResource is the GroupVersionResource of the CDI DataVolume
+// custom resource that KubeVirt uses to import and stage disk images.
+var dataVolumeResource = schema.GroupVersionResource{
+	Group:    "cdi.kubevirt.io",
+	Version:  "v1beta1",
+	Resource: "datavolumes",
+}
+
+// unikornAnnotationPrefix namespaces the Unikorn metadata on a DataVolume,
+// mirroring the "unikorn:" property prefix used by the OpenStack backend.
+const unikornAnnotationPrefix = "unikorn.cloud/"
+
+// Source lists DataVolumes visible to the configured Kubernetes cluster.
+type Source struct {
+	client    dynamic.Interface
+	namespace string
+}
+
+// New creates a KubeVirt image source. kubeconfig selects a kubeconfig
+// file path; an empty string falls back to the standard loading rules
+// (KUBECONFIG environment variable, then the default file, then in-cluster
+// configuration). namespace restricts the search to a single namespace; an
+// empty string searches all namespaces.
+func New(kubeconfig, namespace string) (*Source, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfig != "" {
+		loadingRules.ExplicitPath = kubeconfig
+	}
+
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("loading kubeconfig: %w", err)
+	}
+
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("creating dynamic client: %w", err)
+	}
+
+	return &Source{
+		client:    client,
+		namespace: namespace,
+	}, nil
+}
+
+// List implements providers.ImageSource.
+func (s *Source) List(ctx context.Context) ([]providers.Image, error) {
+	resourceClient := s.client.Resource(dataVolumeResource).Namespace(s.namespace)
+
+	list, err := resourceClient.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing datavolumes: %w", err)
+	}
+
+	out := make([]providers.Image, 0, len(list.Items))
+
+	for i := range list.Items {
+		out = append(out, convert(&list.Items[i]))
+	}
+
+	return out, nil
+}
+
+// convert translates a DataVolume unstructured object into the normalized
+// model. Unikorn metadata is read from the "unikorn.cloud/" annotation
+// namespace and re-keyed to the "unikorn:" property namespace so the
+// schema validator can treat it identically to other backends.
+func convert(dv *unstructured.Unstructured) providers.Image {
+	properties := make(map[string]any)
+
+	for key, value := range dv.GetAnnotations() {
+		name, ok := strings.CutPrefix(key, unikornAnnotationPrefix)
+		if !ok {
+			continue
+		}
+
+		properties["unikorn:"+name] = value
+	}
+
+	return providers.Image{
+		ID:         string(dv.GetUID()),
+		Name:       dv.GetName(),
+		CreatedAt:  dv.GetCreationTimestamp().Time,
+		SizeBytes:  storageRequestBytes(dv),
+		Properties: properties,
+	}
+}
+
+// storageRequestBytes reads the requested storage size from a DataVolume's
+// spec.storage.resources.requests.storage field, returning zero if it is
+// absent or malformed.
+func storageRequestBytes(dv *unstructured.Unstructured) int64 {
+	value, found, err := unstructured.NestedString(dv.Object, "spec", "storage", "resources", "requests", "storage")
+	if err != nil || !found {
+		return 0
+	}
+
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0
+	}
+
+	return quantity.Value()
+}
@@ -0,0 +1,117 @@
+// Package oci implements a providers.ImageSource backed by an OCI image
+// repository, as resolved by go-containerregistry. Unikorn metadata is
+// read from the image manifest's annotations rather than Glance-style
+// properties.
+package oci
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+
+	"github.com/spjmurray/unikorn-images/providers"
+)
+
+// unikornAnnotationPrefix namespaces the Unikorn metadata on an OCI image,
+// mirroring the "unikorn:" property prefix used by the OpenStack backend.
+const unikornAnnotationPrefix = "cloud.unikorn.image/"
+
+// Source lists the tags of a single OCI repository and resolves each one
+// to an image.
+type Source struct {
+	repository string
+	options    []remote.Option
+}
+
+// New creates an OCI image source for the given repository reference,
+// e.g. "registry.example.com/unikorn/images".
+func New(repository string, options ...remote.Option) *Source {
+	return &Source{
+		repository: repository,
+		options:    options,
+	}
+}
+
+// List implements providers.ImageSource.
+func (s *Source) List(ctx context.Context) ([]providers.Image, error) {
+	repo, err := name.NewRepository(s.repository)
+	if err != nil {
+		return nil, fmt.Errorf("parsing repository %q: %w", s.repository, err)
+	}
+
+	options := append([]remote.Option{remote.WithContext(ctx)}, s.options...)
+
+	tags, err := remote.List(repo, options...)
+	if err != nil {
+		return nil, fmt.Errorf("listing tags for %q: %w", s.repository, err)
+	}
+
+	out := make([]providers.Image, 0, len(tags))
+
+	for _, tag := range tags {
+		image, err := s.resolve(repo, tag, options)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, image)
+	}
+
+	return out, nil
+}
+
+// resolve fetches a single tag's manifest and config and converts it into
+// the normalized model.
+func (s *Source) resolve(repo name.Repository, tag string, options []remote.Option) (providers.Image, error) {
+	ref, err := name.NewTag(repo.String()+":"+tag, name.WithDefaultRegistry(repo.RegistryStr()))
+	if err != nil {
+		return providers.Image{}, fmt.Errorf("parsing tag %q: %w", tag, err)
+	}
+
+	img, err := remote.Image(ref, options...)
+	if err != nil {
+		return providers.Image{}, fmt.Errorf("fetching image %q: %w", ref, err)
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return providers.Image{}, fmt.Errorf("getting digest for %q: %w", ref, err)
+	}
+
+	size, err := img.Size()
+	if err != nil {
+		return providers.Image{}, fmt.Errorf("getting size for %q: %w", ref, err)
+	}
+
+	configFile, err := img.ConfigFile()
+	if err != nil {
+		return providers.Image{}, fmt.Errorf("getting config for %q: %w", ref, err)
+	}
+
+	manifest, err := img.Manifest()
+	if err != nil {
+		return providers.Image{}, fmt.Errorf("getting manifest for %q: %w", ref, err)
+	}
+
+	properties := make(map[string]any)
+
+	for key, value := range manifest.Annotations {
+		annotation, ok := strings.CutPrefix(key, unikornAnnotationPrefix)
+		if !ok {
+			continue
+		}
+
+		properties["unikorn:"+annotation] = value
+	}
+
+	return providers.Image{
+		ID:         digest.String(),
+		Name:       ref.String(),
+		CreatedAt:  configFile.Created.Time,
+		SizeBytes:  size,
+		Properties: properties,
+	}, nil
+}
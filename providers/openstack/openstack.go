@@ -0,0 +1,114 @@
+// Package openstack implements a providers.ImageSource backed by the
+// OpenStack Image service (Glance).
+package openstack
+
+import (
+	"context"
+	"io"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack"
+	"github.com/gophercloud/gophercloud/v2/openstack/config"
+	"github.com/gophercloud/gophercloud/v2/openstack/config/clouds"
+	"github.com/gophercloud/gophercloud/v2/openstack/image/v2/imagedata"
+	"github.com/gophercloud/gophercloud/v2/openstack/image/v2/images"
+
+	"github.com/spjmurray/unikorn-images/providers"
+)
+
+// Source lists public images visible to the configured OpenStack cloud.
+// Credentials are resolved the standard gophercloud way, via clouds.yaml
+// or the OS_* environment variables.
+type Source struct{}
+
+// New creates an OpenStack image source.
+func New() *Source {
+	return &Source{}
+}
+
+// client authenticates against the configured OpenStack cloud and returns
+// an Image service client.
+func (s *Source) client(ctx context.Context) (*gophercloud.ServiceClient, error) {
+	authOptions, endpointOpts, tlsConfig, err := clouds.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	providerClient, err := config.NewProviderClient(ctx, authOptions, config.WithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, err
+	}
+
+	return openstack.NewImageV2(providerClient, endpointOpts)
+}
+
+// List implements providers.ImageSource.
+func (s *Source) List(ctx context.Context) ([]providers.Image, error) {
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &images.ListOpts{
+		Visibility: images.ImageVisibilityPublic,
+	}
+
+	page, err := images.List(client, opts).AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := images.ExtractImages(page)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]providers.Image, len(result))
+
+	for i := range result {
+		out[i] = convert(&result[i])
+	}
+
+	return out, nil
+}
+
+// Download streams the raw image data for id, as used to verify or
+// compute the "unikorn:digest" property.
+func (s *Source) Download(ctx context.Context, id string) (io.ReadCloser, error) {
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return imagedata.Download(ctx, client, id).Extract()
+}
+
+// SetProperties patches the named properties on image id, adding them if
+// they do not already exist.
+func (s *Source) SetProperties(ctx context.Context, id string, properties map[string]string) error {
+	client, err := s.client(ctx)
+	if err != nil {
+		return err
+	}
+
+	opts := make(images.UpdateOpts, 0, len(properties))
+
+	for name, value := range properties {
+		opts = append(opts, images.UpdateImageProperty{Op: images.AddOp, Name: name, Value: value})
+	}
+
+	_, err = images.Update(ctx, client, id, opts).Extract()
+
+	return err
+}
+
+// convert translates a gophercloud image into the normalized model.
+func convert(image *images.Image) providers.Image {
+	return providers.Image{
+		ID:         image.ID,
+		Name:       image.Name,
+		CreatedAt:  image.CreatedAt,
+		SizeBytes:  image.SizeBytes,
+		Properties: image.Properties,
+	}
+}
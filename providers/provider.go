@@ -0,0 +1,53 @@
+// Package providers defines the backend-agnostic image model shared by the
+// concrete sources in its subpackages (openstack, kubevirt, oci).
+package providers
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Image is a normalized view of a single image, translated from whatever
+// backend-specific representation (an OpenStack image, a KubeVirt
+// DataVolume, an OCI manifest) the source backend discovered it from.
+type Image struct {
+	// ID uniquely identifies the image within its backend.
+	ID string `json:"id" yaml:"id"`
+
+	// Name is the human readable name of the image.
+	Name string `json:"name" yaml:"name"`
+
+	// CreatedAt records when the image was created.
+	CreatedAt time.Time `json:"createdAt" yaml:"createdAt"`
+
+	// SizeBytes is the size of the underlying image data.
+	SizeBytes int64 `json:"sizeBytes" yaml:"sizeBytes"`
+
+	// Properties holds the Unikorn metadata properties (the
+	// "unikorn:*" namespace) in whatever native type the backend stored
+	// them as, ready for schema validation.
+	Properties map[string]any `json:"properties" yaml:"properties"`
+}
+
+// ImageSource lists images discovered from a single backend. The
+// schema-validation and reporting logic in main is backend-agnostic and
+// only ever talks to this interface.
+type ImageSource interface {
+	// List returns every image visible to the source. Backends are
+	// expected to return all images, including those without Unikorn
+	// properties; callers filter as required.
+	List(ctx context.Context) ([]Image, error)
+}
+
+// Downloader is implemented by sources that can stream an image's raw
+// content, as required to verify or compute its content digest.
+type Downloader interface {
+	Download(ctx context.Context, id string) (io.ReadCloser, error)
+}
+
+// PropertySetter is implemented by sources that can persist metadata
+// properties back onto an image, as required by the signing workflow.
+type PropertySetter interface {
+	SetProperties(ctx context.Context, id string, properties map[string]string) error
+}
@@ -0,0 +1,90 @@
+// Package render encodes a set of reports in the output format requested
+// by a command's --output flag, so the same report can feed a human
+// terminal or a CI pipeline without the caller caring which.
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Format selects how Write renders records.
+type Format string
+
+// The output formats supported by every subcommand that renders reports.
+const (
+	Text   Format = "text"
+	YAML   Format = "yaml"
+	JSON   Format = "json"
+	NDJSON Format = "ndjson"
+)
+
+// ParseFormat validates a --output flag value.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case Text, YAML, JSON, NDJSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("render: unknown output format %q", s)
+	}
+}
+
+// Record is a single reportable item: Text renders its legacy
+// human-readable block (only called for the text format, so callers can
+// defer the work), Payload is the value marshaled for the structured
+// formats.
+type Record struct {
+	Text    func() string
+	Payload any
+}
+
+// Write encodes records in format to w.
+func Write(w io.Writer, format Format, records []Record) error {
+	switch format {
+	case Text, "":
+		for _, record := range records {
+			fmt.Fprintln(w, record.Text())
+		}
+
+		return nil
+	case JSON:
+		payloads := make([]any, len(records))
+		for i, record := range records {
+			payloads[i] = record.Payload
+		}
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(payloads)
+	case NDJSON:
+		enc := json.NewEncoder(w)
+
+		for _, record := range records {
+			if err := enc.Encode(record.Payload); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case YAML:
+		payloads := make([]any, len(records))
+		for i, record := range records {
+			payloads[i] = record.Payload
+		}
+
+		data, err := yaml.Marshal(payloads)
+		if err != nil {
+			return err
+		}
+
+		_, err = w.Write(data)
+
+		return err
+	default:
+		return fmt.Errorf("render: unknown output format %q", format)
+	}
+}
@@ -0,0 +1,160 @@
+// Package scan runs Trivy against downloaded image content and
+// summarizes the resulting CVEs by severity.
+//
+// Rather than booting an ephemeral compute instance, a scan operates on
+// image content obtained through providers.Downloader and handed to
+// Trivy's own "--vm" rootfs mode, which scans a disk image directly
+// without requiring it to be mounted. This keeps scanning backend
+// agnostic: any source that can stream its image data can be scanned.
+package scan
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+)
+
+// SeverityOrder lists Trivy's severities from most to least severe.
+var SeverityOrder = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "UNKNOWN"}
+
+// vulnerability is the subset of a Trivy finding this tool cares about.
+type vulnerability struct {
+	ID       string `json:"VulnerabilityID"`
+	Severity string `json:"Severity"`
+}
+
+// trivyReport mirrors enough of Trivy's "--format json" output to extract
+// vulnerabilities; fields this tool doesn't need are ignored by
+// encoding/json.
+type trivyReport struct {
+	Results []struct {
+		Vulnerabilities []vulnerability `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+// Report summarizes a single Trivy scan.
+type Report struct {
+	// Counts maps a Trivy severity to the number of findings at that
+	// severity.
+	Counts map[string]int
+
+	// Top holds up to ten of the CVE IDs found, highest severity first.
+	Top []string
+
+	// JSON is the raw Trivy report, as produced by --format json.
+	JSON []byte
+}
+
+// Run scans the disk image at path in VM mode and returns a summarized
+// report. It shells out to the trivy binary, which must be on PATH.
+func Run(ctx context.Context, path string) (*Report, error) {
+	if _, err := exec.LookPath("trivy"); err != nil {
+		return nil, fmt.Errorf("scan: trivy not found on PATH: %w", err)
+	}
+
+	var stdout bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "trivy", "rootfs", "--vm", "--format", "json", path)
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("scan: running trivy: %w", err)
+	}
+
+	return parse(stdout.Bytes())
+}
+
+// WriteSARIF re-runs trivy against path, requesting SARIF output directly
+// at outputPath, for ingestion by CI systems.
+func WriteSARIF(ctx context.Context, path, outputPath string) error {
+	if _, err := exec.LookPath("trivy"); err != nil {
+		return fmt.Errorf("scan: trivy not found on PATH: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, "trivy", "rootfs", "--vm", "--format", "sarif", "--output", outputPath, path)
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("scan: running trivy for SARIF: %w", err)
+	}
+
+	return nil
+}
+
+func parse(data []byte) (*Report, error) {
+	var report trivyReport
+
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("scan: parsing trivy report: %w", err)
+	}
+
+	counts := make(map[string]int, len(SeverityOrder))
+
+	var vulns []vulnerability
+
+	for _, result := range report.Results {
+		for _, vuln := range result.Vulnerabilities {
+			counts[vuln.Severity]++
+
+			vulns = append(vulns, vuln)
+		}
+	}
+
+	sort.SliceStable(vulns, func(i, j int) bool {
+		return severityRank(vulns[i].Severity) < severityRank(vulns[j].Severity)
+	})
+
+	top := make([]string, 0, 10)
+
+	for _, vuln := range vulns {
+		if len(top) == 10 {
+			break
+		}
+
+		top = append(top, vuln.ID)
+	}
+
+	return &Report{Counts: counts, Top: top, JSON: data}, nil
+}
+
+// Exceeds reports whether the report has any finding at or above
+// threshold on Trivy's CRITICAL > HIGH > MEDIUM > LOW > UNKNOWN scale.
+func (r *Report) Exceeds(threshold string) bool {
+	idx := severityIndex(threshold)
+	if idx < 0 {
+		return false
+	}
+
+	for _, severity := range SeverityOrder[:idx+1] {
+		if r.Counts[severity] > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func severityIndex(severity string) int {
+	for i, s := range SeverityOrder {
+		if s == severity {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// severityRank orders a vulnerability's severity for sorting Top,
+// placing unrecognized severities after every known one.
+func severityRank(severity string) int {
+	if idx := severityIndex(severity); idx >= 0 {
+		return idx
+	}
+
+	return len(SeverityOrder)
+}
@@ -0,0 +1,142 @@
+// Package schemas maintains a registry of Unikorn schema versions, each
+// owning its own JSON Schema document, field extraction and text
+// rendering. An image selects its version via its
+// "unikorn:schema_version" property, falling back to DefaultVersion when
+// absent, so new schema revisions can be introduced without breaking
+// images still tagged against an older one.
+package schemas
+
+import (
+	"fmt"
+
+	"github.com/kaptinlin/jsonschema"
+)
+
+// VersionProperty names the image property that selects a schema
+// version.
+const VersionProperty = "unikorn:schema_version"
+
+// DefaultVersion is the schema version used when an image carries no
+// VersionProperty.
+const DefaultVersion = "v2"
+
+// Diagnostic is a single machine-readable schema validation failure, so
+// CI systems can parse validation failures without scraping text output.
+type Diagnostic struct {
+	// Field is the JSON Pointer-style location of the failing value
+	// within the image's properties.
+	Field string `json:"field" yaml:"field"`
+
+	// Keyword is the JSON Schema keyword that failed, e.g. "required" or
+	// "enum".
+	Keyword string `json:"keyword" yaml:"keyword"`
+
+	// Details carries whatever parameters the schema validator attached
+	// to the failure, e.g. the missing properties or the allowed enum
+	// values.
+	Details map[string]any `json:"details,omitempty" yaml:"details,omitempty"`
+}
+
+// Fields is a schema version's extraction of an image's Unikorn
+// properties, ready to be marshaled as JSON/YAML or rendered as text.
+// Implementations are typically small structs, so that encoding/json and
+// yaml.v2 emit the fields in declaration order.
+type Fields interface {
+	// Text renders the fields in this tool's human-readable text format.
+	Text() string
+}
+
+// Result is the outcome of validating one image against one schema
+// version.
+type Result struct {
+	// Valid is whether the image's properties matched the schema.
+	Valid bool
+
+	// Fields is populated when Valid is true.
+	Fields Fields
+
+	// Diagnostics explains why validation failed. It is empty when Valid
+	// is true.
+	Diagnostics []Diagnostic
+}
+
+// Version owns one schema document and the logic to validate an image's
+// properties against it and extract its fields.
+type Version interface {
+	Validate(properties map[string]any) Result
+}
+
+var registry = map[string]Version{}
+
+// Register adds version to the registry under name. It is intended to be
+// called from a version package's init function.
+func Register(name string, version Version) {
+	registry[name] = version
+}
+
+// For returns the schema version that should validate properties,
+// selected by VersionProperty and falling back to DefaultVersion when
+// absent.
+func For(properties map[string]any) (Version, error) {
+	name := DefaultVersion
+
+	if v, ok := properties[VersionProperty].(string); ok && v != "" {
+		name = v
+	}
+
+	version, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("schemas: unknown schema version %q", name)
+	}
+
+	return version, nil
+}
+
+// Compile parses a version's JSON Schema document, panicking if it is
+// malformed. It is intended to be called from a version package's init
+// function, where a malformed document is a programming error.
+func Compile(name, document string) *jsonschema.Schema {
+	compiler := jsonschema.NewCompiler()
+
+	schema, err := compiler.Compile([]byte(document))
+	if err != nil {
+		panic(fmt.Sprintf("schemas/%s: compiling schema: %v", name, err))
+	}
+
+	return schema
+}
+
+// StringProperty returns the string value of key in properties, or the
+// zero value if it is absent or not a string.
+func StringProperty(properties map[string]any, key string) string {
+	s, _ := properties[key].(string)
+	return s
+}
+
+// Diagnose flattens a jsonschema evaluation result into machine-readable
+// diagnostics, recursing into nested sub-schema failures.
+func Diagnose(result *jsonschema.EvaluationResult) []Diagnostic {
+	var diagnostics []Diagnostic
+
+	for _, r := range flatten(result) {
+		for keyword, evaluationError := range r.Errors {
+			diagnostics = append(diagnostics, Diagnostic{
+				Field:   r.InstanceLocation,
+				Keyword: keyword,
+				Details: evaluationError.Params,
+			})
+		}
+	}
+
+	return diagnostics
+}
+
+func flatten(result *jsonschema.EvaluationResult) []*jsonschema.EvaluationResult {
+	results := []*jsonschema.EvaluationResult{result}
+
+	for _, detail := range result.Details {
+		results = append(results, flatten(detail)...)
+	}
+
+	return results
+}
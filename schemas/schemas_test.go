@@ -0,0 +1,109 @@
+package schemas
+
+import (
+	"testing"
+
+	"github.com/kaptinlin/jsonschema"
+)
+
+type fakeVersion struct {
+	marker string
+}
+
+func (f fakeVersion) Validate(map[string]any) Result {
+	return Result{Valid: true}
+}
+
+func TestForDefaultsWhenVersionPropertyAbsent(t *testing.T) {
+	Register(DefaultVersion, fakeVersion{marker: "default"})
+
+	version, err := For(map[string]any{})
+	if err != nil {
+		t.Fatalf("For returned error: %v", err)
+	}
+
+	if got := version.(fakeVersion).marker; got != "default" {
+		t.Fatalf("For returned version %q, want %q", got, "default")
+	}
+}
+
+func TestForHonorsVersionProperty(t *testing.T) {
+	Register("v1", fakeVersion{marker: "v1"})
+
+	version, err := For(map[string]any{VersionProperty: "v1"})
+	if err != nil {
+		t.Fatalf("For returned error: %v", err)
+	}
+
+	if got := version.(fakeVersion).marker; got != "v1" {
+		t.Fatalf("For returned version %q, want %q", got, "v1")
+	}
+}
+
+func TestForUnknownVersion(t *testing.T) {
+	if _, err := For(map[string]any{VersionProperty: "does-not-exist"}); err == nil {
+		t.Fatal("For returned nil error for an unregistered schema version")
+	}
+}
+
+func TestDiagnoseFlattensNestedDetails(t *testing.T) {
+	result := &jsonschema.EvaluationResult{
+		InstanceLocation: "",
+		Errors: map[string]*jsonschema.EvaluationError{
+			"required": {Keyword: "required", Params: map[string]interface{}{"property": "'unikorn:virtualization'"}},
+		},
+		Details: []*jsonschema.EvaluationResult{
+			{
+				InstanceLocation: "/unikorn:os:family",
+				Errors: map[string]*jsonschema.EvaluationError{
+					"enum": {Keyword: "enum"},
+				},
+			},
+		},
+	}
+
+	diagnostics := Diagnose(result)
+
+	if len(diagnostics) != 2 {
+		t.Fatalf("Diagnose returned %d diagnostics, want 2: %+v", len(diagnostics), diagnostics)
+	}
+
+	var sawRoot, sawNested bool
+
+	for _, d := range diagnostics {
+		switch d.Field {
+		case "":
+			if d.Keyword != "required" {
+				t.Errorf("root diagnostic keyword = %q, want %q", d.Keyword, "required")
+			}
+
+			sawRoot = true
+		case "/unikorn:os:family":
+			if d.Keyword != "enum" {
+				t.Errorf("nested diagnostic keyword = %q, want %q", d.Keyword, "enum")
+			}
+
+			sawNested = true
+		}
+	}
+
+	if !sawRoot || !sawNested {
+		t.Fatalf("Diagnose did not surface both the root and nested failure: %+v", diagnostics)
+	}
+}
+
+func TestStringProperty(t *testing.T) {
+	properties := map[string]any{"unikorn:os:kernel": "linux", "unikorn:os:variant": 1}
+
+	if got := StringProperty(properties, "unikorn:os:kernel"); got != "linux" {
+		t.Errorf("StringProperty(kernel) = %q, want %q", got, "linux")
+	}
+
+	if got := StringProperty(properties, "unikorn:os:variant"); got != "" {
+		t.Errorf("StringProperty(variant) = %q, want empty string for a non-string value", got)
+	}
+
+	if got := StringProperty(properties, "unikorn:os:missing"); got != "" {
+		t.Errorf("StringProperty(missing) = %q, want empty string for an absent key", got)
+	}
+}
@@ -0,0 +1,114 @@
+// Package v1 implements the original Unikorn schema, predating GPU,
+// virtualization and digest/signature metadata. It is retained so
+// images still tagged "unikorn:schema_version": "v1" keep validating.
+package v1
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kaptinlin/jsonschema"
+
+	"github.com/spjmurray/unikorn-images/schemas"
+)
+
+const document = `{
+	"type":"object",
+	"required":[
+	  "unikorn:os:kernel",
+	  "unikorn:os:family",
+	  "unikorn:os:distro",
+	  "unikorn:os:version"
+	],
+	"properties":{
+		"unikorn:os:kernel":{
+			"type":"string",
+			"enum":[
+				"linux"
+			]
+		},
+		"unikorn:os:family":{
+			"type":"string",
+			"enum":[
+				"debian",
+				"redhat"
+			]
+		},
+		"unikorn:os:distro":{
+			"type":"string",
+			"enum":[
+				"ubuntu",
+				"rocky"
+			]
+		},
+		"unikorn:os:variant":{
+			"type":"string"
+		},
+		"unikorn:os:codename":{
+			"type":"string"
+		},
+		"unikorn:os:version":{
+			"type":"string"
+		}
+	}
+}`
+
+func init() {
+	schemas.Register("v1", &version{schema: schemas.Compile("v1", document)})
+}
+
+type version struct {
+	schema *jsonschema.Schema
+}
+
+// OS describes the operating system fields of the v1 schema.
+type OS struct {
+	Kernel   string `json:"kernel" yaml:"kernel"`
+	Family   string `json:"family" yaml:"family"`
+	Distro   string `json:"distro" yaml:"distro"`
+	Variant  string `json:"variant,omitempty" yaml:"variant,omitempty"`
+	Codename string `json:"codename,omitempty" yaml:"codename,omitempty"`
+	Version  string `json:"version" yaml:"version"`
+}
+
+// Fields is the v1 schema's extraction of an image's Unikorn properties.
+type Fields struct {
+	OS OS `json:"os" yaml:"os"`
+}
+
+// Text implements schemas.Fields.
+func (f Fields) Text() string {
+	var b strings.Builder
+
+	fmt.Fprintln(&b, "os:")
+	fmt.Fprintln(&b, "  kernel:", f.OS.Kernel)
+	fmt.Fprintln(&b, "  family:", f.OS.Family)
+	fmt.Fprintln(&b, "  distro:", f.OS.Distro)
+	fmt.Fprintln(&b, "  variant:", f.OS.Variant)
+	fmt.Fprintln(&b, "  codename:", f.OS.Codename)
+	fmt.Fprintln(&b, "  version:", f.OS.Version)
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func (v *version) Validate(properties map[string]any) schemas.Result {
+	result := v.schema.Validate(properties)
+	if !result.Valid {
+		return schemas.Result{Diagnostics: schemas.Diagnose(result)}
+	}
+
+	str := func(key string) string { return schemas.StringProperty(properties, key) }
+
+	fields := Fields{
+		OS: OS{
+			Kernel:   str("unikorn:os:kernel"),
+			Family:   str("unikorn:os:family"),
+			Distro:   str("unikorn:os:distro"),
+			Variant:  str("unikorn:os:variant"),
+			Codename: str("unikorn:os:codename"),
+			Version:  str("unikorn:os:version"),
+		},
+	}
+
+	return schemas.Result{Valid: true, Fields: fields}
+}
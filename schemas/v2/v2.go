@@ -0,0 +1,189 @@
+// Package v2 implements the current default Unikorn schema, adding GPU,
+// virtualization, content digest, signature and vulnerability scan
+// report metadata on top of v1.
+package v2
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kaptinlin/jsonschema"
+
+	"github.com/spjmurray/unikorn-images/schemas"
+)
+
+const document = `{
+	"type":"object",
+	"required":[
+	  "unikorn:os:kernel",
+	  "unikorn:os:family",
+	  "unikorn:os:distro",
+	  "unikorn:os:version",
+	  "unikorn:virtualization"
+	],
+	"properties":{
+		"unikorn:os:kernel":{
+			"type":"string",
+			"enum":[
+				"linux"
+			]
+		},
+		"unikorn:os:family":{
+                        "type":"string",
+			"enum":[
+				"debian",
+				"redhat"
+			]
+                },
+		"unikorn:os:distro":{
+                        "type":"string",
+			"enum":[
+				"ubuntu",
+				"rocky"
+			]
+                },
+		"unikorn:os:variant":{
+                        "type":"string"
+                },
+		"unikorn:os:codename":{
+                        "type":"string"
+                },
+		"unikorn:os:version":{
+                        "type":"string"
+                },
+		"unikorn:package:kubernetes":{
+                        "type":"string"
+                },
+		"unikorn:package:slurmd":{
+                        "type":"string"
+                },
+		"unikorn:gpu_vendor":{
+                        "type":"string",
+			"enum":[
+				"AMD",
+				"NVIDIA"
+			]
+                },
+		"unikorn:gpu_models":{
+                        "type":"string"
+                },
+		"unikorn:gpu_driver":{
+                        "type":"string"
+                },
+		"unikorn:virtualization":{
+                        "type":"string",
+			"enum":[
+				"any",
+				"baremetal",
+				"virtualized"
+			]
+                },
+		"unikorn:digest":{
+                        "type":"string"
+                },
+		"unikorn:signature":{
+                        "type":"string"
+                },
+		"unikorn:scan:report":{
+                        "type":"string"
+                }
+	}
+}`
+
+func init() {
+	schemas.Register("v2", &version{schema: schemas.Compile("v2", document)})
+}
+
+type version struct {
+	schema *jsonschema.Schema
+}
+
+// OS describes the operating system fields of the v2 schema.
+type OS struct {
+	Kernel   string `json:"kernel" yaml:"kernel"`
+	Family   string `json:"family" yaml:"family"`
+	Distro   string `json:"distro" yaml:"distro"`
+	Variant  string `json:"variant,omitempty" yaml:"variant,omitempty"`
+	Codename string `json:"codename,omitempty" yaml:"codename,omitempty"`
+	Version  string `json:"version" yaml:"version"`
+}
+
+// Package describes the optional software package fields of the v2
+// schema.
+type Package struct {
+	Kubernetes string `json:"kubernetes,omitempty" yaml:"kubernetes,omitempty"`
+	Slurmd     string `json:"slurmd,omitempty" yaml:"slurmd,omitempty"`
+}
+
+// GPU describes the optional GPU fields of the v2 schema.
+type GPU struct {
+	Vendor string `json:"vendor,omitempty" yaml:"vendor,omitempty"`
+	Models string `json:"models,omitempty" yaml:"models,omitempty"`
+	Driver string `json:"driver,omitempty" yaml:"driver,omitempty"`
+}
+
+// Fields is the v2 schema's extraction of an image's Unikorn properties.
+type Fields struct {
+	OS             OS      `json:"os" yaml:"os"`
+	Package        Package `json:"package" yaml:"package"`
+	GPU            GPU     `json:"gpu" yaml:"gpu"`
+	Virtualization string  `json:"virtualization" yaml:"virtualization"`
+	Digest         string  `json:"digest,omitempty" yaml:"digest,omitempty"`
+}
+
+// Text implements schemas.Fields.
+func (f Fields) Text() string {
+	var b strings.Builder
+
+	fmt.Fprintln(&b, "os:")
+	fmt.Fprintln(&b, "  kernel:", f.OS.Kernel)
+	fmt.Fprintln(&b, "  family:", f.OS.Family)
+	fmt.Fprintln(&b, "  distro:", f.OS.Distro)
+	fmt.Fprintln(&b, "  variant:", f.OS.Variant)
+	fmt.Fprintln(&b, "  codename:", f.OS.Codename)
+	fmt.Fprintln(&b, "  version:", f.OS.Version)
+	fmt.Fprintln(&b, "package:")
+	fmt.Fprintln(&b, "  kubernetes:", f.Package.Kubernetes)
+	fmt.Fprintln(&b, "  slurmd:", f.Package.Slurmd)
+	fmt.Fprintln(&b, "gpu:")
+	fmt.Fprintln(&b, "  vendor:", f.GPU.Vendor)
+	fmt.Fprintln(&b, "  models:", f.GPU.Models)
+	fmt.Fprintln(&b, "  driver:", f.GPU.Driver)
+	fmt.Fprintln(&b, "virtualization:", f.Virtualization)
+	fmt.Fprintln(&b, "digest:", f.Digest)
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func (v *version) Validate(properties map[string]any) schemas.Result {
+	result := v.schema.Validate(properties)
+	if !result.Valid {
+		return schemas.Result{Diagnostics: schemas.Diagnose(result)}
+	}
+
+	str := func(key string) string { return schemas.StringProperty(properties, key) }
+
+	fields := Fields{
+		OS: OS{
+			Kernel:   str("unikorn:os:kernel"),
+			Family:   str("unikorn:os:family"),
+			Distro:   str("unikorn:os:distro"),
+			Variant:  str("unikorn:os:variant"),
+			Codename: str("unikorn:os:codename"),
+			Version:  str("unikorn:os:version"),
+		},
+		Package: Package{
+			Kubernetes: str("unikorn:package:kubernetes"),
+			Slurmd:     str("unikorn:package:slurmd"),
+		},
+		GPU: GPU{
+			Vendor: str("unikorn:gpu_vendor"),
+			Models: str("unikorn:gpu_models"),
+			Driver: str("unikorn:gpu_driver"),
+		},
+		Virtualization: str("unikorn:virtualization"),
+		Digest:         str("unikorn:digest"),
+	}
+
+	return schemas.Result{Valid: true, Fields: fields}
+}